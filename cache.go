@@ -0,0 +1,79 @@
+package namespace
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structFieldInfo is the memoized metadata for a single field of a struct type, derived once from
+// its `ns` tag so repeated lookups don't need to re-walk reflect.StructField on every call.
+type structFieldInfo struct {
+	index       int
+	name        string
+	mapped      bool
+	anonymous   bool
+	passthrough bool // (anonymous && !mapped) || ns == "-"
+}
+
+// structTypeInfo is the memoized metadata for a struct type: its exported fields in declaration
+// order, a name -> field-index map for O(1) resolution of a non-passthrough field, and whether any
+// field requires passthrough recursion at all.
+type structTypeInfo struct {
+	fields         []structFieldInfo
+	byName         map[string]int
+	hasPassthrough bool
+}
+
+// structCache memoizes structTypeInfo by reflect.Type so the field walk driven by `ns` tags only
+// happens once per struct type rather than on every Get/names call.
+var structCache sync.Map // map[reflect.Type]*structTypeInfo
+
+// structInfoOf returns the memoized structTypeInfo for typ, computing and caching it on first use.
+func structInfoOf(typ reflect.Type) *structTypeInfo {
+	if cached, ok := structCache.Load(typ); ok {
+		return cached.(*structTypeInfo)
+	}
+	info := &structTypeInfo{byName: make(map[string]int)}
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name, mapped := Field(f)
+		sf := structFieldInfo{
+			index:       i,
+			name:        name,
+			mapped:      mapped,
+			anonymous:   f.Anonymous,
+			passthrough: (f.Anonymous && !mapped) || name == "-",
+		}
+		info.fields = append(info.fields, sf)
+		if sf.passthrough {
+			info.hasPassthrough = true
+		} else if _, exists := info.byName[name]; !exists {
+			// Keep the first declared field for a given name, matching the linear scan's
+			// first-match-wins behavior when two fields collide on the same ns name.
+			info.byName[name] = len(info.fields) - 1
+		}
+	}
+	actual, _ := structCache.LoadOrStore(typ, info)
+	return actual.(*structTypeInfo)
+}
+
+// ptrDepthCache memoizes, for a reflect.Type, how many Ptr layers must be unwrapped to reach its
+// underlying non-pointer Kind, so derefPtr can bound its dereference loop by a depth computed once
+// per pointer type. Non-pointer values never reach this cache; derefPtr returns them unchanged.
+var ptrDepthCache sync.Map // map[reflect.Type]int
+
+// ptrDepthOf returns the memoized pointer-indirection depth for typ.
+func ptrDepthOf(typ reflect.Type) int {
+	if cached, ok := ptrDepthCache.Load(typ); ok {
+		return cached.(int)
+	}
+	depth := 0
+	for t := typ; t.Kind() == reflect.Ptr; t = t.Elem() {
+		depth++
+	}
+	ptrDepthCache.Store(typ, depth)
+	return depth
+}
@@ -0,0 +1,19 @@
+package namespace
+
+import "testing"
+
+type DuplicateNsName struct {
+	First  string `ns:"Foo"`
+	Second string `ns:"Foo"`
+}
+
+func TestGet_DuplicateNsNameResolvesFirstDeclared(t *testing.T) {
+	v := DuplicateNsName{First: "first", Second: "second"}
+	got, err := Namespace(v, []string{"Foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "first" {
+		t.Fatalf("wanted the first declared field (\"first\"), got %q", got.String())
+	}
+}
@@ -0,0 +1,43 @@
+package namespace
+
+import "reflect"
+
+// CustomTypeFunc converts an opaque value, such as sql.NullString or time.Time, into the value that
+// should be traversed in its place. It is invoked with the current reflect.Value before a lookup
+// dispatches on that value's Kind.
+type CustomTypeFunc func(reflect.Value) reflect.Value
+
+// Config holds CustomTypeFuncs registered against specific types.
+// The zero value of Config has no custom type funcs registered and behaves identically to a nil *Config.
+type Config struct {
+	types map[reflect.Type]CustomTypeFunc
+}
+
+// NewConfig returns a new, empty Config ready to have CustomTypeFuncs registered on it.
+func NewConfig() *Config {
+	return &Config{types: make(map[reflect.Type]CustomTypeFunc)}
+}
+
+// RegisterCustomTypeFunc registers fn to run whenever a value of one of the given types is encountered
+// during traversal, teaching the package to descend into an opaque type it doesn't otherwise understand,
+// such as sql.NullString, time.Time, or a protobuf wrapper type.
+func (c *Config) RegisterCustomTypeFunc(fn CustomTypeFunc, types ...interface{}) {
+	if c.types == nil {
+		c.types = make(map[reflect.Type]CustomTypeFunc)
+	}
+	for _, t := range types {
+		c.types[reflect.TypeOf(t)] = fn
+	}
+}
+
+// apply invokes the CustomTypeFunc registered for v's type, if any, and returns its result.
+// v is returned unchanged if it is invalid or no func is registered for its type.
+func (c *Config) apply(v reflect.Value) reflect.Value {
+	if c == nil || c.types == nil || !v.IsValid() {
+		return v
+	}
+	if fn, ok := c.types[v.Type()]; ok {
+		return fn(v)
+	}
+	return v
+}
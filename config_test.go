@@ -0,0 +1,43 @@
+package namespace
+
+import (
+	"reflect"
+	"testing"
+)
+
+// opaque simulates a wrapper type like *wrapperspb.StringValue: it only exposes an unexported
+// field, so nothing is reachable via plain reflection without a registered CustomTypeFunc.
+type opaque struct {
+	secret string
+}
+
+// opaqueView is what a CustomTypeFunc exposes in place of opaque's unexported internals.
+type opaqueView struct {
+	Value string
+}
+
+type opaqueHolder struct {
+	X *opaque `ns:"-"`
+}
+
+func TestNamespaceWithConfig_CustomTypeFuncOnPointerBehindPassthrough(t *testing.T) {
+	h := &opaqueHolder{X: &opaque{secret: "hi"}}
+
+	// Without a registered func, opaque's only field is unexported, so nothing is reachable.
+	if _, err := Namespace(h, []string{"Value"}); err == nil {
+		t.Fatal("expected an error with no CustomTypeFunc registered")
+	}
+
+	cfg := NewConfig()
+	cfg.RegisterCustomTypeFunc(func(v reflect.Value) reflect.Value {
+		return reflect.ValueOf(opaqueView{Value: v.Interface().(*opaque).secret})
+	}, (*opaque)(nil))
+
+	v, err := NamespaceWithConfig(cfg, h, []string{"Value"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "hi" {
+		t.Fatalf("wanted hi, got %s", v.String())
+	}
+}
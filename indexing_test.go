@@ -0,0 +1,94 @@
+package namespace
+
+import "testing"
+
+type IndexItem struct {
+	Name string
+}
+
+type IndexHolder struct {
+	Items  []IndexItem
+	Arr    [2]string
+	IntMap map[int]string
+}
+
+func TestNamespace_SliceBracketIndexing(t *testing.T) {
+	h := IndexHolder{Items: []IndexItem{{Name: "a"}, {Name: "b"}}}
+
+	v, err := StringNameSpace(h, "Items[0].Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "a" {
+		t.Fatalf("wanted a, got %s", v.String())
+	}
+
+	// Items.0.Name (dot form) addresses the same value as Items[0].Name (bracket form).
+	v, err = StringNameSpace(h, "Items.1.Name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "b" {
+		t.Fatalf("wanted b, got %s", v.String())
+	}
+}
+
+func TestNamespace_SliceOutOfRange(t *testing.T) {
+	h := IndexHolder{Items: []IndexItem{{Name: "a"}}}
+	_, err := StringNameSpace(h, "Items[5].Name")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, ok := err.(NamespaceError); !ok {
+		t.Fatalf("expected a NamespaceError, got %T", err)
+	}
+}
+
+func TestNamespace_ArrayIndexing(t *testing.T) {
+	h := IndexHolder{Arr: [2]string{"x", "y"}}
+	v, err := StringNameSpace(h, "Arr[1]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "y" {
+		t.Fatalf("wanted y, got %s", v.String())
+	}
+}
+
+func TestNamespace_TypedMapIndexing(t *testing.T) {
+	h := IndexHolder{IntMap: map[int]string{7: "seven"}}
+	v, err := StringNameSpace(h, "IntMap[7]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.String() != "seven" {
+		t.Fatalf("wanted seven, got %s", v.String())
+	}
+}
+
+func TestNames_SliceEmitsBracketedIndices(t *testing.T) {
+	h := IndexHolder{Items: []IndexItem{{Name: "a"}, {Name: "b"}}}
+	names := Names(h)
+
+	want := map[string]bool{
+		"Items[0].Name": false,
+		"Items[1].Name": false,
+	}
+	for _, n := range names {
+		key := ""
+		for i, p := range n {
+			if i > 0 && p[0] != '[' {
+				key += "."
+			}
+			key += p
+		}
+		if _, ok := want[key]; ok {
+			want[key] = true
+		}
+	}
+	for k, found := range want {
+		if !found {
+			t.Fatalf("expected Names() to include %q, got %#v", k, names)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"github.com/renstrom/fuzzysearch/fuzzy"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -39,9 +40,15 @@ type Namespacer interface {
 type NamespaceError struct {
 	Suggestions []string
 	Ns          string
+	// Message, when set, overrides the default "not found" message.
+	// This is used to give more helpful context, such as a slice index being out of range.
+	Message string
 }
 
 func (ns NamespaceError) Error() string {
+	if ns.Message != "" {
+		return ns.Message
+	}
 	s := fmt.Sprintf("Name %q not found in object", ns.Ns)
 	if len(ns.Suggestions) > 0 {
 		s = s + fmt.Sprintf(" (Did you mean %q?)", strings.Join(ns.Suggestions, ", "))
@@ -104,20 +111,27 @@ func (v Value) String() string {
 // Namespace gets a value by the given namespaces in order.
 // If the length of namespace is emtpy then the object itself is returned.
 func Namespace(i interface{}, namespaces []string) (Value, error) {
+	return NamespaceWithConfig(nil, i, namespaces)
+}
+
+// NamespaceWithConfig is like Namespace but resolves cfg's registered CustomTypeFuncs against every
+// value encountered during traversal, before that value's Kind is inspected. A nil cfg behaves like
+// an empty Config.
+func NamespaceWithConfig(cfg *Config, i interface{}, namespaces []string) (Value, error) {
 	if i == nil {
 		return Value{}, ErrNilValue
 	}
 	if ns, ok := i.(Namespacer); ok {
 		return ns.Namespace(namespaces)
 	}
-	v := reflect.ValueOf(i)
+	v := cfg.apply(reflect.ValueOf(i))
 	for i := 0; i < len(namespaces); i++ {
 		if ns, ok := v.Interface().(Namespacer); ok {
 			return ns.Namespace(namespaces[i:])
 		}
-		n := Get(v, namespaces[i])
+		n := cfg.apply(get(cfg, v, namespaces[i]))
 		if !n.IsValid() {
-			return Value{}, NamespaceError{Ns: namespaces[i], Suggestions: suggest(v, namespaces[i])}
+			return Value{}, namespaceError(cfg, v, namespaces[i])
 		}
 		v = n
 		if v.Kind() == reflect.Interface {
@@ -127,9 +141,144 @@ func Namespace(i interface{}, namespaces []string) (Value, error) {
 	return Value{Value: v}, nil
 }
 
+// StringNameSpace is like Namespace but accepts a single string path instead of a slice of namespaces.
+// The path is split on '.', with a bracketed segment such as [0] treated as a single segment of its own,
+// so `Items[0].Name` and `Items.0.Name` both address the same value.
+func StringNameSpace(i interface{}, path string) (Value, error) {
+	return Namespace(i, splitPath(path))
+}
+
+// StringNameSpaceWithConfig combines NamespaceWithConfig and StringNameSpace: it resolves cfg's
+// registered CustomTypeFuncs during traversal of a single string path.
+func StringNameSpaceWithConfig(cfg *Config, i interface{}, path string) (Value, error) {
+	return NamespaceWithConfig(cfg, i, splitPath(path))
+}
+
+// splitPath tokenizes a string path into the namespace segments consumed by Namespace.
+// It splits on '.' outside of brackets, and keeps a bracketed segment, e.g. [0], intact as its own segment.
+func splitPath(path string) []string {
+	var segments []string
+	var buf strings.Builder
+	depth := 0
+	flush := func() {
+		if buf.Len() > 0 {
+			segments = append(segments, buf.String())
+			buf.Reset()
+		}
+	}
+	for _, r := range path {
+		switch {
+		case r == '[':
+			flush()
+			depth++
+			buf.WriteRune(r)
+		case r == ']':
+			buf.WriteRune(r)
+			depth--
+			if depth == 0 {
+				flush()
+			}
+		case r == '.' && depth == 0:
+			flush()
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	flush()
+	return segments
+}
+
+// namespaceError builds the error returned when name cannot be found within v,
+// giving a bounds-specific message when v is a slice or array.
+func namespaceError(cfg *Config, v reflect.Value, name string) error {
+	if rv := indirect(v); rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		if idx, ok := parseIndex(name); ok {
+			return NamespaceError{
+				Ns:      name,
+				Message: fmt.Sprintf("index %d out of range for %q (len %d)", idx, name, rv.Len()),
+			}
+		}
+	}
+	return NamespaceError{Ns: name, Suggestions: suggest(cfg, v, name)}
+}
+
+// indirect dereferences an interface or chain of pointers down to the concrete value.
+func indirect(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	return derefPtr(v)
+}
+
+// derefPtr dereferences a chain of pointers down to the concrete value, using the cached pointer
+// depth to bound the loop.
+func derefPtr(v reflect.Value) reflect.Value {
+	if !v.IsValid() || v.Kind() != reflect.Ptr {
+		return v
+	}
+	for depth := ptrDepthOf(v.Type()); depth > 0 && v.Kind() == reflect.Ptr; depth-- {
+		v = v.Elem()
+	}
+	return v
+}
+
+// trimBrackets strips a leading '[' and trailing ']' from a bracketed segment, e.g. "[0]" -> "0".
+// Segments without brackets, e.g. "0", are returned unchanged.
+func trimBrackets(segment string) string {
+	if len(segment) >= 2 && segment[0] == '[' && segment[len(segment)-1] == ']' {
+		return segment[1 : len(segment)-1]
+	}
+	return segment
+}
+
+// parseIndex parses a (optionally bracketed) segment into a slice or array index.
+func parseIndex(segment string) (int, bool) {
+	idx, err := strconv.Atoi(trimBrackets(segment))
+	if err != nil {
+		return 0, false
+	}
+	return idx, true
+}
+
+// convertMapKey converts a (optionally bracketed) segment into a reflect.Value suitable for
+// indexing a map whose key type is not a string, e.g. int, uint, float, or bool keyed maps.
+func convertMapKey(segment string, keyType reflect.Type) (reflect.Value, bool) {
+	segment = trimBrackets(segment)
+	key := reflect.New(keyType).Elem()
+	switch keyType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(segment, 10, 64)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(segment, 10, 64)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(segment, 64)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(segment)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		key.SetBool(b)
+	default:
+		return reflect.Value{}, false
+	}
+	return key, true
+}
+
 // suggest suggests the closest matches to the requested namespace name.
-func suggest(v reflect.Value, name string) (res []string) {
-	names := names(v, nil)
+func suggest(cfg *Config, v reflect.Value, name string) (res []string) {
+	names := names(cfg, v, nil)
 	if len(names) == 0 {
 		return
 	}
@@ -160,35 +309,56 @@ func Field(v reflect.StructField) (name string, mapped bool) {
 }
 
 // Get gets a value from a given value using the given name.
+// name may be a plain segment, e.g. "0", or a bracketed segment, e.g. "[0]", when v is a slice,
+// array, or a map whose key is not a string.
 func Get(v reflect.Value, name string) reflect.Value {
+	return get(nil, v, name)
+}
+
+// get is Get's implementation, resolving cfg's registered CustomTypeFuncs before dispatching on v's Kind.
+// cfg is applied after unwrapping an interface but before dereferencing pointers, the same order
+// names uses, so a CustomTypeFunc registered on a pointer type (e.g. *wrapperspb.StringValue) is
+// invoked here exactly when it would be found by suggest's use of names.
+func get(cfg *Config, v reflect.Value, name string) reflect.Value {
 	if v.Kind() == reflect.Interface {
 		v = v.Elem()
 	}
-	// dereference pointers
-	for v.Kind() == reflect.Ptr {
-		v = v.Elem()
-	}
+	v = derefPtr(cfg.apply(v))
 	switch v.Kind() {
 	case reflect.Struct:
-		typ := v.Type()
-		for i := 0; i < typ.NumField(); i++ {
-			f := typ.Field(i)
-			if f.PkgPath != "" {
-				continue
+		info := structInfoOf(v.Type())
+		if !info.hasPassthrough {
+			if i, ok := info.byName[name]; ok {
+				return v.Field(info.fields[i].index)
 			}
-			ns, mapped := Field(f)
-			if (f.Anonymous && !mapped) || ns == "-" {
-				nV := Get(v.Field(i), name)
+			return reflect.Value{}
+		}
+		for _, sf := range info.fields {
+			if sf.passthrough {
+				nV := get(cfg, v.Field(sf.index), name)
 				if nV.IsValid() {
 					return nV
 				}
 			}
-			if ns == name {
-				return v.Field(i)
+			if sf.name == name {
+				return v.Field(sf.index)
 			}
 		}
 	case reflect.Map:
-		return v.MapIndex(reflect.ValueOf(name))
+		if v.Type().Key().Kind() == reflect.String {
+			return v.MapIndex(reflect.ValueOf(name))
+		}
+		key, ok := convertMapKey(name, v.Type().Key())
+		if !ok {
+			return reflect.Value{}
+		}
+		return v.MapIndex(key)
+	case reflect.Slice, reflect.Array:
+		idx, ok := parseIndex(name)
+		if !ok || idx < 0 || idx >= v.Len() {
+			return reflect.Value{}
+		}
+		return v.Index(idx)
 	}
 	return reflect.Value{}
 }
@@ -207,10 +377,11 @@ type Namer interface {
 // which is useful for recursive access.
 func Names(v interface{}, prev ...string) [][]string {
 	val := reflect.ValueOf(v)
-	return names(val, prev)
+	return names(nil, val, prev)
 }
 
-func names(v reflect.Value, prev []string) (ns [][]string) {
+// names is Names's implementation, resolving cfg's registered CustomTypeFuncs before recursing.
+func names(cfg *Config, v reflect.Value, prev []string) (ns [][]string) {
 	switch v.Kind() {
 	case reflect.Map, reflect.Ptr:
 		if v.IsNil() {
@@ -227,6 +398,7 @@ func names(v reflect.Value, prev []string) (ns [][]string) {
 		}
 		v = v.Elem()
 	}
+	v = cfg.apply(v)
 	if nmr, ok := v.Interface().(Namer); ok {
 		return nmr.Names(prev)
 	}
@@ -236,19 +408,15 @@ func names(v reflect.Value, prev []string) (ns [][]string) {
 
 	switch v.Kind() {
 	case reflect.Struct:
-		for i := 0; i < v.NumField(); i++ {
-			f := v.Type().Field(i)
-			if f.PkgPath != "" {
-				continue
-			}
-			n, mapped := Field(f)
-			if (f.Anonymous && !mapped) || n == "-" {
-				ns = append(ns, names(v.Field(i), prev)...)
+		info := structInfoOf(v.Type())
+		for _, sf := range info.fields {
+			if sf.passthrough {
+				ns = append(ns, names(cfg, v.Field(sf.index), prev)...)
 				continue
 			}
-			tn := names(v.Field(i), append(prev, n))
+			tn := names(cfg, v.Field(sf.index), append(prev, sf.name))
 			if tn == nil {
-				ns = append(ns, append(prev, n))
+				ns = append(ns, append(prev, sf.name))
 				continue
 			}
 			ns = append(ns, tn...)
@@ -268,11 +436,33 @@ func names(v reflect.Value, prev []string) (ns [][]string) {
 			}
 			switch kv.Kind() {
 			case reflect.Map, reflect.Struct:
-				ns = append(ns, names(kv, append(prev, k.String()))...)
+				ns = append(ns, names(cfg, kv, append(prev, k.String()))...)
 			default:
 				ns = append(ns, append(prev, k.String()))
 			}
 		}
+	case reflect.Slice, reflect.Array:
+		for idx := 0; idx < v.Len(); idx++ {
+			p := appendIndex(prev, idx)
+			tn := names(cfg, v.Index(idx), p)
+			if tn == nil {
+				ns = append(ns, p)
+				continue
+			}
+			ns = append(ns, tn...)
+		}
 	}
 	return
 }
+
+// appendIndex suffixes a bracketed index onto the last segment of prev, e.g. ["Items"], 0 -> ["Items[0]"].
+// If prev is empty the index itself becomes the only segment, e.g. 0 -> ["[0]"].
+func appendIndex(prev []string, idx int) []string {
+	if len(prev) == 0 {
+		return []string{fmt.Sprintf("[%d]", idx)}
+	}
+	p := make([]string, len(prev))
+	copy(p, prev)
+	p[len(p)-1] = fmt.Sprintf("%s[%d]", p[len(p)-1], idx)
+	return p
+}
@@ -0,0 +1,204 @@
+package namespace
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// A Settable is an object that can assign its own namespace value.
+// If a type implements Settable then that method is used instead of reflect traversal.
+type Settable interface {
+	Set([]string, interface{}) error
+}
+
+// SetError describes why a value could not be assigned at a given namespace path.
+type SetError struct {
+	Ns      string
+	Message string
+	// notFound marks an error as meaning "name does not exist here", as opposed to a concrete
+	// failure (wrong type, unaddressable, out of range) once name has actually been located. It lets
+	// setField's passthrough search keep trying sibling fields without swallowing a real failure.
+	notFound bool
+}
+
+func (e SetError) Error() string {
+	return fmt.Sprintf("cannot set %q: %s", e.Ns, e.Message)
+}
+
+// Set assigns value at the namespace path given by namespaces within i, traversing using the same
+// rules as Namespace down to the parent of the last segment and assigning into the final field,
+// map entry, or slice/array index there.
+func Set(i interface{}, value interface{}, namespaces ...string) error {
+	if i == nil {
+		return ErrNilValue
+	}
+	if len(namespaces) == 0 {
+		return SetError{Message: "no namespace given"}
+	}
+	if s, ok := i.(Settable); ok {
+		return s.Set(namespaces, value)
+	}
+	v := reflect.ValueOf(i)
+	for n := 0; n < len(namespaces)-1; n++ {
+		if s, ok := v.Interface().(Settable); ok {
+			return s.Set(namespaces[n:], value)
+		}
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return SetError{Ns: namespaces[n], Message: "nil pointer"}
+		}
+		nv := Get(v, namespaces[n])
+		if !nv.IsValid() {
+			return SetError{Ns: namespaces[n], Message: "not found"}
+		}
+		v = nv
+		if v.Kind() == reflect.Interface {
+			v = v.Elem()
+		}
+	}
+	// Check Settable on the parent of the last segment too, the same way Namespace checks
+	// Namespacer at every index including the last, not just on intermediate segments.
+	if s, ok := v.Interface().(Settable); ok {
+		return s.Set(namespaces[len(namespaces)-1:], value)
+	}
+	return setField(v, namespaces[len(namespaces)-1], value)
+}
+
+// StringSet is like Set but accepts a single string path instead of a slice of namespaces,
+// using the same bracket-aware tokenization as StringNameSpace.
+func StringSet(i interface{}, value interface{}, path string) error {
+	return Set(i, value, splitPath(path)...)
+}
+
+// MustSet is like Set but panics if an error is returned.
+func MustSet(i interface{}, value interface{}, namespaces ...string) {
+	if err := Set(i, value, namespaces...); err != nil {
+		panic(err)
+	}
+}
+
+// setField assigns value to name within parent, which must be a struct, map, slice, or array.
+func setField(parent reflect.Value, name string, value interface{}) error {
+	parent = indirect(parent)
+	if !parent.IsValid() {
+		return SetError{Ns: name, Message: "nil pointer", notFound: true}
+	}
+	switch parent.Kind() {
+	case reflect.Struct:
+		info := structInfoOf(parent.Type())
+		// Without any passthrough fields, declaration order can't matter: walk directly via byName,
+		// same as get()'s fast path.
+		if !info.hasPassthrough {
+			if i, ok := info.byName[name]; ok {
+				return assign(parent.Field(info.fields[i].index), name, value)
+			}
+			return SetError{Ns: name, Message: "field not found", notFound: true}
+		}
+		// With passthrough fields present, resolve in the same declaration-order priority get() uses,
+		// so a Set on a path Get just resolved can never land on a different field: for each field,
+		// attempt passthrough recursion before comparing the direct name.
+		for _, sf := range info.fields {
+			if sf.passthrough {
+				err := setField(parent.Field(sf.index), name, value)
+				if err == nil {
+					return nil
+				}
+				// A concrete failure (wrong type, unaddressable, out of range) means name was found in
+				// this branch but couldn't be assigned; surface it instead of masking it by trying
+				// siblings and eventually reporting a misleading "field not found".
+				if se, ok := err.(SetError); !ok || !se.notFound {
+					return err
+				}
+			}
+			if sf.name == name {
+				return assign(parent.Field(sf.index), name, value)
+			}
+		}
+		return SetError{Ns: name, Message: "field not found", notFound: true}
+	case reflect.Map:
+		return setMapField(parent, name, value)
+	case reflect.Slice:
+		return setSliceField(parent, name, value)
+	case reflect.Array:
+		idx, ok := parseIndex(name)
+		if !ok || idx < 0 || idx >= parent.Len() {
+			return SetError{Ns: name, Message: fmt.Sprintf("index %d out of range (len %d)", idx, parent.Len())}
+		}
+		return assign(parent.Index(idx), name, value)
+	}
+	return SetError{Ns: name, Message: fmt.Sprintf("cannot set a field on kind %s", parent.Kind()), notFound: true}
+}
+
+// setMapField assigns value to key name within parent, allocating parent if it is a nil, addressable map
+// and creating the entry if it doesn't already exist.
+func setMapField(parent reflect.Value, name string, value interface{}) error {
+	if parent.IsNil() {
+		if !parent.CanSet() {
+			return SetError{Ns: name, Message: "map is nil and not addressable"}
+		}
+		parent.Set(reflect.MakeMap(parent.Type()))
+	}
+	var key reflect.Value
+	if parent.Type().Key().Kind() == reflect.String {
+		key = reflect.ValueOf(name)
+	} else {
+		k, ok := convertMapKey(name, parent.Type().Key())
+		if !ok {
+			return SetError{Ns: name, Message: fmt.Sprintf("cannot convert %q to map key type %s", name, parent.Type().Key())}
+		}
+		key = k
+	}
+	elemType := parent.Type().Elem()
+	elem, err := convertValue(value, elemType)
+	if err != nil {
+		return SetError{Ns: name, Message: err.Error()}
+	}
+	parent.SetMapIndex(key, elem)
+	return nil
+}
+
+// setSliceField assigns value to index name within parent, growing parent if name indexes beyond its
+// current length and parent is addressable.
+func setSliceField(parent reflect.Value, name string, value interface{}) error {
+	idx, ok := parseIndex(name)
+	if !ok || idx < 0 {
+		return SetError{Ns: name, Message: "invalid index"}
+	}
+	if idx >= parent.Len() {
+		if !parent.CanSet() {
+			return SetError{Ns: name, Message: fmt.Sprintf("index %d out of range (len %d) and slice is not addressable", idx, parent.Len())}
+		}
+		grown := reflect.MakeSlice(parent.Type(), idx+1, idx+1)
+		reflect.Copy(grown, parent)
+		parent.Set(grown)
+	}
+	return assign(parent.Index(idx), name, value)
+}
+
+// assign sets dst to value, converting value to dst's type if it isn't already assignable.
+func assign(dst reflect.Value, name string, value interface{}) error {
+	if !dst.CanSet() {
+		return SetError{Ns: name, Message: "value is not settable"}
+	}
+	v, err := convertValue(value, dst.Type())
+	if err != nil {
+		return SetError{Ns: name, Message: err.Error()}
+	}
+	dst.Set(v)
+	return nil
+}
+
+// convertValue converts value into a reflect.Value assignable to typ, converting it if it isn't
+// already directly assignable.
+func convertValue(value interface{}, typ reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(typ), nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(typ) {
+		return rv, nil
+	}
+	if rv.Type().ConvertibleTo(typ) {
+		return rv.Convert(typ), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot assign %s to %s", rv.Type(), typ)
+}
@@ -0,0 +1,142 @@
+package namespace
+
+import (
+	"errors"
+	"testing"
+)
+
+type SetChild struct {
+	Value string
+}
+
+type SetParent struct {
+	Child SetChild
+	Tags  map[string]string
+	Items []string
+}
+
+func TestSet(t *testing.T) {
+	p := &SetParent{}
+	if err := Set(p, "hello", "Child", "Value"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Child.Value != "hello" {
+		t.Fatalf("wanted hello, got %s", p.Child.Value)
+	}
+
+	if err := Set(p, "v", "Tags", "k"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Tags["k"] != "v" {
+		t.Fatalf("wanted v, got %s", p.Tags["k"])
+	}
+
+	if err := Set(p, "second", "Items", "[1]"); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Items) != 2 || p.Items[1] != "second" {
+		t.Fatalf("wanted len 2 with Items[1]=second, got %#v", p.Items)
+	}
+}
+
+func TestStringSet(t *testing.T) {
+	p := &SetParent{}
+	if err := StringSet(p, "hello", "Child.Value"); err != nil {
+		t.Fatal(err)
+	}
+	if p.Child.Value != "hello" {
+		t.Fatalf("wanted hello, got %s", p.Child.Value)
+	}
+}
+
+// settableRecorder implements Settable and records the path/value it was asked to set.
+type settableRecorder struct {
+	gotPath  []string
+	gotValue interface{}
+	err      error
+}
+
+func (s *settableRecorder) Set(path []string, value interface{}) error {
+	s.gotPath = path
+	s.gotValue = value
+	return s.err
+}
+
+type SettableHolder struct {
+	Inner *settableRecorder
+}
+
+func TestSet_SettableAtFinalSegment(t *testing.T) {
+	// A Settable one level deep, reached by the *last* path segment, must still have its Set method
+	// invoked instead of falling through to reflection.
+	inner := &settableRecorder{}
+	h := &SettableHolder{Inner: inner}
+
+	if err := Set(h, "value", "Inner", "Key"); err != nil {
+		t.Fatal(err)
+	}
+	if len(inner.gotPath) != 1 || inner.gotPath[0] != "Key" {
+		t.Fatalf("wanted path [Key], got %#v", inner.gotPath)
+	}
+	if inner.gotValue != "value" {
+		t.Fatalf("wanted value, got %v", inner.gotValue)
+	}
+}
+
+type PassThroughNumber struct {
+	Container struct {
+		Value int
+	} `ns:"-"`
+}
+
+func TestSet_PassthroughFailureIsNotSwallowed(t *testing.T) {
+	// Container does hold a "Value" field, but the supplied value can't be assigned to it. setField
+	// must surface that concrete failure rather than exhausting the passthrough search and reporting
+	// a generic "field not found".
+	p := &PassThroughNumber{}
+	err := Set(p, struct{}{}, "Value")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	var se SetError
+	if !errors.As(err, &se) {
+		t.Fatalf("expected a SetError, got %T: %v", err, err)
+	}
+	if se.Message == "field not found" {
+		t.Fatalf("wanted the concrete passthrough failure surfaced, got the generic not-found message")
+	}
+}
+
+type SetBase struct {
+	Name string
+}
+
+type SetDerived struct {
+	SetBase
+	Name string
+}
+
+func TestSet_PassthroughLiteralCollisionMatchesGet(t *testing.T) {
+	// Name is shadowed: SetBase (field 0, passthrough) is checked before the literal Name field
+	// (field 1), so Namespace/Get resolve "Name" to the promoted SetBase.Name. Set must target the
+	// same field, or a read-then-write round trip on the same path silently corrupts the wrong one.
+	d := &SetDerived{SetBase: SetBase{Name: "base-name"}, Name: "derived-name"}
+
+	got, err := Namespace(*d, []string{"Name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.String() != "base-name" {
+		t.Fatalf("wanted Namespace to resolve the promoted SetBase.Name, got %q", got.String())
+	}
+
+	if err := Set(d, "NEW", "Name"); err != nil {
+		t.Fatal(err)
+	}
+	if d.SetBase.Name != "NEW" {
+		t.Fatalf("wanted Set to target the same field Namespace resolved (SetBase.Name), got %q", d.SetBase.Name)
+	}
+	if d.Name != "derived-name" {
+		t.Fatalf("wanted the shadowed literal Name field left untouched, got %q", d.Name)
+	}
+}
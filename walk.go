@@ -0,0 +1,187 @@
+package namespace
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// pathSegment is one step of a path built up during a walk, tagged with whether it came from
+// indexing into a slice/array (as opposed to a struct field or map key) so Flatten can tell the two
+// apart when deciding how to render the segment.
+type pathSegment struct {
+	value string
+	index bool
+}
+
+// Walk performs a depth-first traversal of v using the same struct/map/slice rules as Names,
+// invoking fn at every leaf (a non-struct, non-map, non-slice, non-array value) with the path
+// accumulated to reach it and that leaf's Value. Traversal stops and returns the first error fn returns.
+// Unlike Names, map entries with non-string keys are visited too, keyed by the key's String form.
+func Walk(v interface{}, fn func(path []string, val Value) error) error {
+	if v == nil {
+		return ErrNilValue
+	}
+	return walkSegments(reflect.ValueOf(v), nil, true, func(segs []pathSegment, leaf reflect.Value) error {
+		path := make([]string, len(segs))
+		for i, s := range segs {
+			path[i] = s.value
+		}
+		return fn(path, Value{Value: leaf})
+	})
+}
+
+// walkSegments is Walk's implementation, tracking whether each segment of the path was produced by
+// slice/array indexing so that Flatten can later choose how to render it. honorPassthrough selects
+// whether an anonymous or `ns:"-"` field is recursed into inline, under its parent's path, or treated
+// like any other named field, under a segment of its own.
+func walkSegments(v reflect.Value, prev []pathSegment, honorPassthrough bool, fn func([]pathSegment, reflect.Value) error) error {
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		info := structInfoOf(v.Type())
+		for _, sf := range info.fields {
+			if sf.passthrough && honorPassthrough {
+				if err := walkSegments(v.Field(sf.index), prev, honorPassthrough, fn); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := walkSegments(v.Field(sf.index), appendSegment(prev, sf.name, false), honorPassthrough, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			key := k.String()
+			if k.Kind() != reflect.String {
+				key = Value{Value: k}.String()
+			}
+			if err := walkSegments(v.MapIndex(k), appendSegment(prev, key, false), honorPassthrough, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Slice, reflect.Array:
+		for idx := 0; idx < v.Len(); idx++ {
+			if err := walkSegments(v.Index(idx), appendSegment(prev, strconv.Itoa(idx), true), honorPassthrough, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fn(prev, v)
+}
+
+// appendSegment returns a copy of prev with a new segment appended, so sibling branches of the walk
+// don't alias each other's backing array.
+func appendSegment(prev []pathSegment, value string, index bool) []pathSegment {
+	p := make([]pathSegment, len(prev)+1)
+	copy(p, prev)
+	p[len(p)-1] = pathSegment{value: value, index: index}
+	return p
+}
+
+// flattenConfig holds the resolved settings for a Flatten call.
+type flattenConfig struct {
+	separator        string
+	bracketIndex     bool
+	includeZero      bool
+	honorPassthrough bool
+}
+
+// FlattenOption configures a Flatten or FlattenValues call.
+type FlattenOption func(*flattenConfig)
+
+// WithSeparator sets the separator joining path segments. The default separator is ".".
+func WithSeparator(sep string) FlattenOption {
+	return func(c *flattenConfig) { c.separator = sep }
+}
+
+// WithBracketIndex selects whether slice and array indices are rendered as a bracketed suffix on the
+// preceding segment, e.g. "Items[0]", instead of being joined like any other segment, e.g. "Items.0".
+// This is off by default.
+func WithBracketIndex(enabled bool) FlattenOption {
+	return func(c *flattenConfig) { c.bracketIndex = enabled }
+}
+
+// WithZeroValues selects whether leaves holding their type's zero value are included in the output.
+// This is off by default.
+func WithZeroValues(include bool) FlattenOption {
+	return func(c *flattenConfig) { c.includeZero = include }
+}
+
+// WithPassthrough selects whether an anonymous or `ns:"-"` field is recursed into inline, promoting
+// its children onto its parent's path, the same as Get and Namespace do. This is on by default; disable
+// it to instead flatten such a field under a segment of its own, named after the field.
+func WithPassthrough(enabled bool) FlattenOption {
+	return func(c *flattenConfig) { c.honorPassthrough = enabled }
+}
+
+func newFlattenConfig(opts []FlattenOption) *flattenConfig {
+	c := &flattenConfig{separator: ".", honorPassthrough: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// key renders segs into a single flattened key according to c.
+func (c *flattenConfig) key(segs []pathSegment) string {
+	var b strings.Builder
+	for i, s := range segs {
+		if s.index && c.bracketIndex {
+			b.WriteByte('[')
+			b.WriteString(s.value)
+			b.WriteByte(']')
+			continue
+		}
+		if i > 0 {
+			b.WriteString(c.separator)
+		}
+		b.WriteString(s.value)
+	}
+	return b.String()
+}
+
+// Flatten walks v and returns its leaves as a flat map keyed by their joined path, suitable for
+// serializing an arbitrary struct into a config map or an HTTP form post.
+func Flatten(v interface{}, opts ...FlattenOption) (map[string]string, error) {
+	if v == nil {
+		return nil, ErrNilValue
+	}
+	cfg := newFlattenConfig(opts)
+	out := make(map[string]string)
+	err := walkSegments(reflect.ValueOf(v), nil, cfg.honorPassthrough, func(segs []pathSegment, leaf reflect.Value) error {
+		if !cfg.includeZero && leaf.IsZero() {
+			return nil
+		}
+		out[cfg.key(segs)] = Value{Value: leaf}.String()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FlattenValues is like Flatten but returns a url.Values, ready to be used as an HTTP form post body.
+func FlattenValues(v interface{}, opts ...FlattenOption) (url.Values, error) {
+	m, err := Flatten(v, opts...)
+	if err != nil {
+		return nil, err
+	}
+	values := make(url.Values, len(m))
+	for k, val := range m {
+		values.Set(k, val)
+	}
+	return values, nil
+}
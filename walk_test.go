@@ -0,0 +1,150 @@
+package namespace
+
+import "testing"
+
+type WalkChild struct {
+	Name string
+}
+
+type WalkRoot struct {
+	Title string
+	Zero  string
+	Items []WalkChild
+	Tags  map[string]string
+}
+
+func TestWalk(t *testing.T) {
+	v := WalkRoot{
+		Title: "hello",
+		Items: []WalkChild{{Name: "a"}, {Name: "b"}},
+		Tags:  map[string]string{"k": "v"},
+	}
+
+	got := make(map[string]string)
+	err := Walk(v, func(path []string, val Value) error {
+		key := ""
+		for i, p := range path {
+			if i > 0 {
+				key += "."
+			}
+			key += p
+		}
+		got[key] = val.String()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"Title":        "hello",
+		"Zero":         "",
+		"Items.0.Name": "a",
+		"Items.1.Name": "b",
+		"Tags.k":       "v",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("wanted %s=%q, got %q (full: %#v)", k, v, got[k], got)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	v := WalkRoot{
+		Title: "hello",
+		Items: []WalkChild{{Name: "a"}},
+	}
+
+	m, err := Flatten(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["Title"] != "hello" {
+		t.Fatalf("wanted hello, got %s", m["Title"])
+	}
+	if m["Items.0.Name"] != "a" {
+		t.Fatalf("wanted a, got %s", m["Items.0.Name"])
+	}
+	if _, ok := m["Zero"]; ok {
+		t.Fatalf("expected zero-valued leaves to be excluded by default, got %#v", m)
+	}
+}
+
+func TestFlatten_WithZeroValues(t *testing.T) {
+	v := WalkRoot{}
+	m, err := Flatten(v, WithZeroValues(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["Title"]; !ok {
+		t.Fatalf("expected zero-valued leaves to be included, got %#v", m)
+	}
+}
+
+func TestFlatten_WithBracketIndex(t *testing.T) {
+	v := WalkRoot{Items: []WalkChild{{Name: "a"}}}
+	m, err := Flatten(v, WithBracketIndex(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["Items[0].Name"]; !ok {
+		t.Fatalf("expected bracketed index key, got %#v", m)
+	}
+}
+
+func TestFlatten_WithSeparator(t *testing.T) {
+	v := WalkRoot{Title: "hello", Items: []WalkChild{{Name: "a"}}}
+	m, err := Flatten(v, WithSeparator("/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["Title"] != "hello" {
+		t.Fatalf("wanted hello, got %#v", m)
+	}
+	if m["Items/0/Name"] != "a" {
+		t.Fatalf("expected nested segments joined by the custom separator, got %#v", m)
+	}
+}
+
+type WalkPassthroughChild struct {
+	Value string
+}
+
+type WalkPassthroughParent struct {
+	WalkPassthroughChild
+}
+
+func TestFlatten_WithPassthrough(t *testing.T) {
+	v := WalkPassthroughParent{WalkPassthroughChild: WalkPassthroughChild{Value: "a"}}
+
+	m, err := Flatten(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["Value"] != "a" {
+		t.Fatalf("expected the passthrough field's child promoted onto the parent path, got %#v", m)
+	}
+
+	m, err = Flatten(v, WithPassthrough(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m["WalkPassthroughChild.Value"] != "a" {
+		t.Fatalf("expected the passthrough field nested under its own name with WithPassthrough(false), got %#v", m)
+	}
+	if _, ok := m["Value"]; ok {
+		t.Fatalf("did not expect the promoted key with WithPassthrough(false), got %#v", m)
+	}
+}
+
+func TestFlattenValues(t *testing.T) {
+	v := WalkRoot{Title: "hello"}
+	values, err := FlattenValues(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values.Get("Title") != "hello" {
+		t.Fatalf("wanted hello, got %s", values.Get("Title"))
+	}
+}